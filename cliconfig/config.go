@@ -108,18 +108,22 @@ func (configFile *ConfigFile) LegacyLoadFromReader(configData io.Reader) error {
 }
 
 // LoadFromReader reads the configuration data given and sets up the auth config
-// information with given directory and populates the receiver object
+// information with given directory and populates the receiver object.
+// The data may also be a Kubernetes .dockerconfigjson secret, whose entries
+// can carry a plain username and password instead of the encoded auth field.
 func (configFile *ConfigFile) LoadFromReader(configData io.Reader) error {
 	if err := json.NewDecoder(configData).Decode(&configFile); err != nil {
 		return err
 	}
 	var err error
 	for addr, ac := range configFile.AuthConfigs {
-		ac.Username, ac.Password, err = decodeAuth(ac.Auth)
-		if err != nil {
-			return err
+		if ac.Auth != "" {
+			ac.Username, ac.Password, err = decodeAuth(ac.Auth)
+			if err != nil {
+				return err
+			}
+			ac.Auth = ""
 		}
-		ac.Auth = ""
 		ac.ServerAddress = addr
 		configFile.AuthConfigs[addr] = ac
 	}
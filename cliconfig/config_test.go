@@ -372,6 +372,27 @@ func TestJsonReaderNoFile(t *testing.T) {
 
 }
 
+func TestDockerConfigJSONSecret(t *testing.T) {
+	// The payload of a kubernetes.io/dockerconfigjson secret: entries are
+	// keyed by bare hostname and may omit the encoded auth field.
+	js := `{"auths":{"registry.example.com":{"username":"robot","password":"s3cret","email":"robot@example.com"},"other.example.com:5000":{"auth":"am9lam9lOmhlbGxv"}}}`
+
+	config, err := LoadFromReader(strings.NewReader(js))
+	if err != nil {
+		t.Fatalf("Failed loading dockerconfigjson secret: %q", err)
+	}
+
+	ac := config.AuthConfigs["registry.example.com"]
+	if ac.Username != "robot" || ac.Password != "s3cret" || ac.Email != "robot@example.com" || ac.ServerAddress != "registry.example.com" {
+		t.Fatalf("Missing data from parsing:\n%q", config)
+	}
+
+	ac = config.AuthConfigs["other.example.com:5000"]
+	if ac.Username != "joejoe" || ac.Password != "hello" || ac.Auth != "" {
+		t.Fatalf("Missing data from parsing:\n%q", config)
+	}
+}
+
 func TestOldJsonReaderNoFile(t *testing.T) {
 	js := `{"https://index.docker.io/v1/":{"auth":"am9lam9lOmhlbGxv","email":"user@example.com"}}`
 
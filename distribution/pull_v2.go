@@ -467,7 +467,14 @@ func (p *v2Puller) pullSchema1(ctx context.Context, ref reference.Named, unverif
 		return "", "", err
 	}
 
-	manifestDigest = digest.FromBytes(unverifiedManifest.Canonical)
+	// If pull by digest, report the requested digest, which
+	// verifySchema1Manifest has already checked and which may use an
+	// algorithm other than the canonical one.
+	if digested, isDigested := ref.(reference.Canonical); isDigested {
+		manifestDigest = digested.Digest()
+	} else {
+		manifestDigest = digest.FromBytes(unverifiedManifest.Canonical)
+	}
 
 	return imageID, manifestDigest, nil
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/docker/distribution/digest"
 	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/distribution/manifest/schema2"
 	"github.com/docker/docker/reference"
 )
 
@@ -172,3 +173,106 @@ func TestValidateManifest(t *testing.T) {
 		t.Fatal("expected validateManifest to fail with digest error")
 	}
 }
+
+// TestValidateManifestHistoryLength verifies that verifySchema1Manifest
+// rejects manifests whose History does not line up with FSLayers, instead of
+// letting later code index past the end of History.
+func TestValidateManifestHistoryLength(t *testing.T) {
+	ref, err := reference.ParseNamed("library/hello-world:latest")
+	if err != nil {
+		t.Fatal("could not parse reference")
+	}
+
+	// Mismatched manifest
+
+	mismatchedManifestBytes, err := ioutil.ReadFile("fixtures/validate_manifest/mismatched_history_manifest")
+	if err != nil {
+		t.Fatal("error reading fixture:", err)
+	}
+
+	var mismatchedSignedManifest schema1.SignedManifest
+	err = json.Unmarshal(mismatchedManifestBytes, &mismatchedSignedManifest)
+	if err != nil {
+		t.Fatal("error unmarshaling manifest:", err)
+	}
+
+	if len(mismatchedSignedManifest.FSLayers) == len(mismatchedSignedManifest.History) {
+		t.Fatal("fixture should have fewer history entries than layers")
+	}
+
+	_, err = verifySchema1Manifest(&mismatchedSignedManifest, ref)
+	if err == nil || !strings.HasPrefix(err.Error(), "length of history not equal to number of layers") {
+		t.Fatalf("expected validateManifest to fail with history length error, got %v", err)
+	}
+
+	// Empty manifest
+
+	emptySignedManifest := schema1.SignedManifest{
+		Manifest: schema1.Manifest{
+			Versioned: mismatchedSignedManifest.Versioned,
+		},
+	}
+
+	_, err = verifySchema1Manifest(&emptySignedManifest, ref)
+	if err == nil || !strings.HasPrefix(err.Error(), "no FSLayers in manifest") {
+		t.Fatalf("expected validateManifest to fail with no FSLayers error, got %v", err)
+	}
+}
+
+// TestValidateManifestSHA512 makes sure that pulling by a digest which does
+// not use sha256 verifies against that digest's own algorithm.
+func TestValidateManifestSHA512(t *testing.T) {
+	goodManifestBytes, err := ioutil.ReadFile("fixtures/validate_manifest/good_manifest")
+	if err != nil {
+		t.Fatal("error reading fixture:", err)
+	}
+
+	var goodSignedManifest schema1.SignedManifest
+	err = json.Unmarshal(goodManifestBytes, &goodSignedManifest)
+	if err != nil {
+		t.Fatal("error unmarshaling manifest:", err)
+	}
+
+	dgst := digest.SHA512.FromBytes(goodSignedManifest.Canonical)
+	ref, err := reference.ParseNamed("repo@" + dgst.String())
+	if err != nil {
+		t.Fatal("could not parse reference:", err)
+	}
+	if ref.String() != "repo@"+dgst.String() {
+		t.Fatalf("unexpected reference %s", ref.String())
+	}
+
+	if _, err := verifySchema1Manifest(&goodSignedManifest, ref); err != nil {
+		t.Fatal("validateManifest failed:", err)
+	}
+
+	wrongRef, err := reference.ParseNamed("repo@" + digest.SHA512.FromBytes(goodManifestBytes).String())
+	if err != nil {
+		t.Fatal("could not parse reference:", err)
+	}
+	_, err = verifySchema1Manifest(&goodSignedManifest, wrongRef)
+	if err == nil || !strings.HasPrefix(err.Error(), "image verification failed for digest") {
+		t.Fatalf("expected validateManifest to fail with digest error, got %v", err)
+	}
+
+	mfst, err := schema2.FromStruct(schema2.Manifest{Versioned: schema2.SchemaVersion})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, canonical, err := mfst.Payload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dgst = digest.SHA512.FromBytes(canonical)
+	ref, err = reference.ParseNamed("repo@" + dgst.String())
+	if err != nil {
+		t.Fatal("could not parse reference:", err)
+	}
+	manifestDigest, err := schema2ManifestDigest(ref, mfst)
+	if err != nil {
+		t.Fatal("schema2ManifestDigest failed:", err)
+	}
+	if manifestDigest != dgst {
+		t.Fatalf("expected digest %s, got %s", dgst, manifestDigest)
+	}
+}
@@ -1,8 +1,8 @@
 package distribution
 
 import (
+	"crypto/tls"
 	"fmt"
-	"net"
 	"net/http"
 	"net/url"
 	"strings"
@@ -37,20 +37,7 @@ func NewV2Repository(ctx context.Context, repoInfo *registry.RepositoryInfo, end
 		repoName = repoInfo.RemoteName()
 	}
 
-	// TODO(dmcgowan): Call close idle connections when complete, use keep alive
-	base := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		Dial: (&net.Dialer{
-			Timeout:   30 * time.Second,
-			KeepAlive: 30 * time.Second,
-			DualStack: true,
-		}).Dial,
-		TLSHandshakeTimeout: 10 * time.Second,
-		TLSClientConfig:     endpoint.TLSConfig,
-		// TODO(dmcgowan): Call close idle connections when complete and use keep alive
-		DisableKeepAlives: true,
-	}
-
+	base := registry.NewTransport(endpoint.TLSConfig)
 	modifiers := registry.DockerHeaders(dockerversion.DockerUserAgent(), metaHeaders)
 	authTransport := transport.NewTransport(base, modifiers...)
 	pingClient := &http.Client{
@@ -110,6 +97,18 @@ func NewV2Repository(ctx context.Context, repoInfo *registry.RepositoryInfo, end
 	return repo, foundVersion, err
 }
 
+// newV1Client returns a HTTP client for talking to a v1 registry. It adds
+// Docker-specific headers as well as user-specified headers (metaHeaders) to
+// every request.
+func newV1Client(tlsConfig *tls.Config, metaHeaders http.Header) *http.Client {
+	tr := transport.NewTransport(
+		// TODO(tiborvass): pull was ReceiveTimeout, push was NoTimeout
+		registry.NewTransport(tlsConfig),
+		registry.DockerHeaders(dockerversion.DockerUserAgent(), metaHeaders)...,
+	)
+	return registry.HTTPClient(tr)
+}
+
 type existingTokenHandler struct {
 	token string
 }
@@ -125,3 +125,42 @@ func TestTokenPassThruDifferentHost(t *testing.T) {
 		t.Fatal("Redirect should not forward Authorization header to another host")
 	}
 }
+
+// TestPullFallbackOnPingFailure checks that a v2 endpoint whose /v2/ ping
+// cannot be reached is reported as a fallback error, so that Pull moves on
+// to the next endpoint instead of failing outright.
+func TestPullFallbackOnPingFailure(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	// Close the server straight away so that connecting to it fails.
+	ts.Close()
+
+	endpoint := registry.APIEndpoint{
+		URL:     ts.URL,
+		Version: registry.APIVersion2,
+	}
+	n, _ := reference.ParseNamed("testremotename")
+	repoInfo := &registry.RepositoryInfo{
+		Named: n,
+		Index: &registrytypes.IndexInfo{
+			Name: "testrepo",
+		},
+	}
+	imagePullConfig := &ImagePullConfig{
+		MetaHeaders: http.Header{},
+		AuthConfig:  &types.AuthConfig{},
+	}
+	puller, err := newPuller(endpoint, repoInfo, imagePullConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tag, _ := reference.WithTag(n, "tag_goes_here")
+	err = puller.Pull(context.Background(), tag)
+	fallbackErr, ok := err.(fallbackError)
+	if !ok {
+		t.Fatalf("expected a fallbackError, got %#v", err)
+	}
+	if fallbackErr.confirmedV2 {
+		t.Fatal("unreachable endpoint should not be confirmed as v2")
+	}
+}
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/image"
+	"github.com/docker/go-connections/nat"
+)
+
+const sampleV1ConfigJSON = `{
+	"id": "3b38edc92eb7c074812e217b41a6ade66888531009d6286a6f5f36a06f9841b9",
+	"parent": "ec3025ca8cc9bcab039e193e20ec647c2da3c53a74020f2ba611601f9b2c6c02",
+	"created": "2015-08-19T16:49:11.368300679Z",
+	"docker_version": "%s",
+	"config": {
+		"ExposedPorts": {"53/udp": {}, "80/tcp": {}},
+		"Volumes": {"/data": {}},
+		"WorkingDir": "/go",
+		"Entrypoint": ["/go/bin/dnsdock"],
+		"Cmd": ["--verbose"]
+	},
+	"architecture": "amd64",
+	"os": "linux",
+	"Size": 0
+}`
+
+// TestMakeConfigFromV1ConfigKeepsConfig makes sure that the container
+// configuration carried in a schema1 v1Compatibility entry survives the
+// conversion to an image config, both with and without the fallback used for
+// configs written by old daemons.
+func TestMakeConfigFromV1ConfigKeepsConfig(t *testing.T) {
+	for _, dockerVersion := range []string{"1.6.2", "1.10.0"} {
+		imageJSON := []byte(fmt.Sprintf(sampleV1ConfigJSON, dockerVersion))
+
+		configJSON, err := MakeConfigFromV1Config(imageJSON, image.NewRootFS(), []image.History{})
+		if err != nil {
+			t.Fatalf("%s: %v", dockerVersion, err)
+		}
+
+		img, err := image.NewFromJSON(configJSON)
+		if err != nil {
+			t.Fatalf("%s: %v", dockerVersion, err)
+		}
+		if img.Config == nil {
+			t.Fatalf("%s: config was dropped", dockerVersion)
+		}
+
+		if len(img.Config.ExposedPorts) != 2 {
+			t.Fatalf("%s: unexpected ExposedPorts: %v", dockerVersion, img.Config.ExposedPorts)
+		}
+		for _, port := range []string{"53/udp", "80/tcp"} {
+			if _, ok := img.Config.ExposedPorts[nat.Port(port)]; !ok {
+				t.Fatalf("%s: missing exposed port %s", dockerVersion, port)
+			}
+		}
+		if !reflect.DeepEqual(img.Config.Volumes, map[string]struct{}{"/data": {}}) {
+			t.Fatalf("%s: unexpected Volumes: %v", dockerVersion, img.Config.Volumes)
+		}
+		if img.Config.WorkingDir != "/go" {
+			t.Fatalf("%s: unexpected WorkingDir: %q", dockerVersion, img.Config.WorkingDir)
+		}
+		if !reflect.DeepEqual(img.Config.Entrypoint.Slice(), []string{"/go/bin/dnsdock"}) {
+			t.Fatalf("%s: unexpected Entrypoint: %v", dockerVersion, img.Config.Entrypoint)
+		}
+		if !reflect.DeepEqual(img.Config.Cmd.Slice(), []string{"--verbose"}) {
+			t.Fatalf("%s: unexpected Cmd: %v", dockerVersion, img.Config.Cmd)
+		}
+	}
+}
@@ -21,6 +21,8 @@ const (
 	DefaultRepoPrefix = "library/"
 )
 
+var errRemoteNameNotLowercase = errors.New("invalid reference format: repository name must be lowercase")
+
 // Named is an object with a full name
 type Named interface {
 	// Name returns normalized repository name, like "ubuntu".
@@ -55,6 +57,9 @@ type Canonical interface {
 func ParseNamed(s string) (Named, error) {
 	named, err := distreference.ParseNamed(s)
 	if err != nil {
+		if hasUppercaseRemoteName(s) {
+			return nil, fmt.Errorf("Error parsing reference: %q is not a valid repository/tag: %v", s, errRemoteNameNotLowercase)
+		}
 		return nil, fmt.Errorf("Error parsing reference: %q is not a valid repository/tag", s)
 	}
 	r, err := WithName(named.Name())
@@ -174,12 +179,31 @@ func splitHostname(name string) (hostname, remoteName string) {
 	return
 }
 
+// hasUppercaseRemoteName reports whether the reference s would be valid if
+// its repository path were lowercase. The hostname is left alone, as it is
+// case-insensitive and already accepted in mixed case.
+func hasUppercaseRemoteName(s string) bool {
+	name, suffix := s, ""
+	if i := strings.IndexRune(s, '@'); i != -1 {
+		name, suffix = s[:i], s[i:]
+	}
+	host, remoteName := "", name
+	if i := strings.IndexRune(name, '/'); i != -1 && (strings.ContainsAny(name[:i], ".:") || name[:i] == "localhost") {
+		host, remoteName = name[:i+1], name[i+1:]
+	}
+	if strings.ToLower(remoteName) == remoteName {
+		return false
+	}
+	_, err := distreference.ParseNamed(host + strings.ToLower(remoteName) + suffix)
+	return err == nil
+}
+
 // normalize returns a repository name in its normalized form, meaning it
 // will not contain default hostname nor library/ prefix for official images.
 func normalize(name string) (string, error) {
 	host, remoteName := splitHostname(name)
 	if strings.ToLower(remoteName) != remoteName {
-		return "", errors.New("invalid reference format: repository name must be lowercase")
+		return "", errRemoteNameNotLowercase
 	}
 	if host == DefaultHostname {
 		if strings.HasPrefix(remoteName, DefaultRepoPrefix) {
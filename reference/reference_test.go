@@ -1,6 +1,7 @@
 package reference
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/docker/distribution/digest"
@@ -119,6 +120,40 @@ func TestValidateRemoteName(t *testing.T) {
 	}
 }
 
+func TestParseNamedUppercase(t *testing.T) {
+	uppercaseNames := []string{
+		"docker/Docker",
+		"Docker",
+		"docker.io/docker/Docker:latest",
+		"Example.com:5000/Docker/docker",
+		"localhost/Docker@sha256:1234567890098765432112345667890098765432112345667890098765432112",
+	}
+	for _, name := range uppercaseNames {
+		_, err := ParseNamed(name)
+		if err == nil || !strings.Contains(err.Error(), "repository name must be lowercase") {
+			t.Fatalf("Expected lowercase error for %q, got: %v", name, err)
+		}
+	}
+
+	// Uppercase in the hostname is accepted.
+	if _, err := ParseNamed("Example.com:5000/docker/docker"); err != nil {
+		t.Fatalf("Error parsing repo name with mixed-case hostname, got: %q", err)
+	}
+
+	// Names that are invalid for other reasons keep the generic error.
+	otherInvalidNames := []string{
+		"docker///Docker",
+		"-Docker",
+		"docker/docker@sha256:ABCD",
+	}
+	for _, name := range otherInvalidNames {
+		_, err := ParseNamed(name)
+		if err == nil || strings.Contains(err.Error(), "repository name must be lowercase") {
+			t.Fatalf("Expected generic parse error for %q, got: %v", name, err)
+		}
+	}
+}
+
 func TestParseRepositoryInfo(t *testing.T) {
 	type tcase struct {
 		RemoteName, NormalizedName, FullName, AmbiguousName, Hostname string
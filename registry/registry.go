@@ -26,6 +26,11 @@ var (
 	ErrAlreadyExists = errors.New("Image already exists")
 )
 
+// maxRedirects is the number of redirects followed before a request fails.
+// Setting CheckRedirect replaces net/http's own limit, so it is enforced in
+// addRequiredHeadersToRedirectedRequests.
+const maxRedirects = 10
+
 func init() {
 	if runtime.GOOS != "linux" {
 		V2Only = true
@@ -147,8 +152,12 @@ func trustedLocation(req *http.Request) bool {
 }
 
 // addRequiredHeadersToRedirectedRequests adds the necessary redirection headers
-// for redirected requests
+// for redirected requests. It fails once more than maxRedirects redirects
+// have been followed, so a redirect loop does not hang the client.
 func addRequiredHeadersToRedirectedRequests(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("too many redirects: stopped after %d redirects from %s", maxRedirects, via[0].URL)
+	}
 	if via != nil && via[0] != nil {
 		if trustedLocation(req) && trustedLocation(via[0]) {
 			req.Header = via[0].Header
@@ -3,9 +3,11 @@ package registry
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/http/httputil"
 	"net/url"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/docker/distribution/registry/client/transport"
@@ -702,6 +704,132 @@ func TestMirrorEndpointLookup(t *testing.T) {
 	}
 }
 
+func TestLookupEndpointsWithPortAndPath(t *testing.T) {
+	s := Service{Config: makeServiceConfig(nil, []string{"insecure.example.com:5000"})}
+
+	type expectedEndpoints struct {
+		RemoteName string
+		URLs       []string
+	}
+
+	expected := map[string]expectedEndpoints{
+		"registry.example.com:5000/team/app:tag": {
+			RemoteName: "team/app",
+			URLs:       []string{"https://registry.example.com:5000"},
+		},
+		"registry.example.com:5000/org/team/app": {
+			RemoteName: "org/team/app",
+			URLs:       []string{"https://registry.example.com:5000"},
+		},
+		// Everything after the hostname is part of the repository name,
+		// so a registry path prefix is sent as part of /v2/<name>/.
+		"registry.example.com/path/v2-base/team/app": {
+			RemoteName: "path/v2-base/team/app",
+			URLs:       []string{"https://registry.example.com"},
+		},
+		"insecure.example.com:5000/team/app": {
+			RemoteName: "team/app",
+			URLs:       []string{"https://insecure.example.com:5000", "http://insecure.example.com:5000"},
+		},
+	}
+
+	for name, expectedEndpoints := range expected {
+		named, err := reference.ParseNamed(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repoInfo, err := s.ResolveRepository(named)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkEqual(t, repoInfo.RemoteName(), expectedEndpoints.RemoteName, name)
+
+		endpoints, err := s.LookupPullEndpoints(repoInfo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var v2URLs []string
+		for _, endpoint := range endpoints {
+			if endpoint.Version != APIVersion2 {
+				continue
+			}
+			if !endpoint.TrimHostname {
+				t.Errorf("%s: expected TrimHostname for %s", name, endpoint.URL)
+			}
+			v2URLs = append(v2URLs, endpoint.URL)
+		}
+		checkEqual(t, strings.Join(v2URLs, ","), strings.Join(expectedEndpoints.URLs, ","), name)
+	}
+}
+
+func TestServiceConcurrentLookup(t *testing.T) {
+	s := Service{Config: makeServiceConfig([]string{"https://my.mirror"}, []string{"example.com"})}
+
+	hostname := strings.SplitN(makeURL(""), "://", 2)[1]
+	names := []string{
+		IndexName + "/test/image",
+		"example.com/test/image",
+		hostname + "/" + REPO,
+	}
+
+	const workers = 20
+	errs := make(chan error, workers*len(names))
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, name := range names {
+				named, err := reference.ParseNamed(name)
+				if err != nil {
+					errs <- err
+					return
+				}
+				repoInfo, err := s.ResolveRepository(named)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if _, err := s.TLSConfig(repoInfo.Index.Name); err != nil {
+					errs <- err
+					return
+				}
+				endpoints, err := s.LookupPullEndpoints(repoInfo)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if len(endpoints) == 0 {
+					errs <- fmt.Errorf("no endpoints found for %s", name)
+					return
+				}
+			}
+
+			// Talk to the mock registry through an index resolved
+			// from the shared service.
+			index, err := s.ResolveIndex(hostname)
+			if err != nil {
+				errs <- err
+				return
+			}
+			ep, err := NewEndpoint(index, "", nil, APIVersion1)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if _, err := ep.Ping(); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
 func TestPushRegistryTag(t *testing.T) {
 	r := spawnTestRegistrySession(t)
 	repoRef, err := reference.ParseNamed(REPO)
@@ -889,3 +1017,25 @@ func (tr debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	tr.log(string(dump))
 	return resp, err
 }
+
+func TestHTTPClientRedirectLoop(t *testing.T) {
+	var hits int
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		http.Redirect(w, r, ts.URL+"/loop", http.StatusFound)
+	}))
+	defer ts.Close()
+
+	resp, err := HTTPClient(http.DefaultTransport).Get(ts.URL + "/v1/_ping")
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("Expected an error for a redirect loop")
+	}
+	if !strings.Contains(err.Error(), "too many redirects") {
+		t.Fatalf("Expected a too many redirects error, got %v", err)
+	}
+	if hits != maxRedirects {
+		t.Fatalf("Expected %d requests before giving up, got %d", maxRedirects, hits)
+	}
+}
@@ -12,7 +12,10 @@ import (
 )
 
 // Service is a registry service. It tracks configuration data such as a list
-// of mirrors.
+// of mirrors. The configuration is not modified after the service is created,
+// so a single Service may be shared by concurrent pulls, pushes and lookups.
+// Callers must treat the returned IndexInfo values as read-only, since
+// configured indexes are shared between all lookups.
 type Service struct {
 	Config *registrytypes.ServiceConfig
 }